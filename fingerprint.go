@@ -0,0 +1,94 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+)
+
+// signatures.json maps a DBMS identifier to its list of error-message
+// regexes. It is embedded so the scanner ships with a working signature set
+// out of the box; -fingerprint-file lets users point at their own copy to
+// extend or replace it without recompiling.
+//
+//go:embed signatures.json
+var embeddedSignatures []byte
+
+// dbmsSignatures holds the compiled signature set currently in use.
+var dbmsSignatures map[string][]*regexp.Regexp
+
+func init() {
+	sigs, err := parseSignatures(embeddedSignatures)
+	if err != nil {
+		panic(fmt.Sprintf("fingerprint: invalid embedded signatures.json: %v", err))
+	}
+	dbmsSignatures = sigs
+}
+
+// parseSignatures compiles a {dbms: [pattern, ...]} JSON document into
+// regexes, keyed by DBMS. The "generic" key holds patterns that indicate a
+// SQL error without identifying a specific engine.
+func parseSignatures(data []byte) (map[string][]*regexp.Regexp, error) {
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	compiled := make(map[string][]*regexp.Regexp, len(raw))
+	for dbms, patterns := range raw {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("dbms %q: %w", dbms, err)
+			}
+			compiled[dbms] = append(compiled[dbms], re)
+		}
+	}
+	return compiled, nil
+}
+
+// loadSignatureFile replaces dbmsSignatures with the contents of path, a
+// JSON file in the same {dbms: [pattern, ...]} shape as signatures.json.
+func loadSignatureFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading fingerprint file: %w", err)
+	}
+	sigs, err := parseSignatures(data)
+	if err != nil {
+		return fmt.Errorf("parsing fingerprint file: %w", err)
+	}
+	dbmsSignatures = sigs
+	return nil
+}
+
+// fingerprintDBMS scans body against every DBMS's signature set and
+// returns the first DBMS whose pattern matched, and whether anything
+// matched at all. A "generic" match (a SQL error that doesn't identify a
+// specific engine) reports matched=true with an empty DBMS name.
+func fingerprintDBMS(body string) (dbms string, matched bool) {
+	names := make([]string, 0, len(dbmsSignatures))
+	for name := range dbmsSignatures {
+		if name != "generic" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, pattern := range dbmsSignatures[name] {
+			if pattern.MatchString(body) {
+				return name, true
+			}
+		}
+	}
+	for _, pattern := range dbmsSignatures["generic"] {
+		if pattern.MatchString(body) {
+			return "", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how performRequest retries a request that fails
+// with a network error or a 5xx response.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// defaultRetryPolicy retries transient failures three times with
+// exponential backoff starting at 500ms (500ms, 1s, 2s).
+var defaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond}
+
+// backoff returns how long to wait before retry attempt n (1-indexed).
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	return r.BaseDelay * time.Duration(uint(1)<<uint(attempt-1))
+}
+
+// newHTTPClient builds the single client shared by every scan request,
+// tuned for many concurrent requests against the same host(s) and,
+// optionally, routed through an upstream HTTP/HTTPS/SOCKS5 proxy.
+func newHTTPClient(proxyAddr string) (*http.Client, error) {
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 50,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	if proxyAddr != "" {
+		if err := configureProxy(transport, proxyAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// configureProxy points transport at an upstream proxy. http/https proxies
+// use the standard library's built-in CONNECT support; socks5 is handled by
+// a minimal client dialer since the standard library has none.
+func configureProxy(transport *http.Transport, proxyAddr string) error {
+	proxyURL, err := url.Parse(proxyAddr)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https":
+		transport.Proxy = http.ProxyURL(proxyURL)
+	case "socks5", "socks5h":
+		transport.DialContext = socks5DialContext(proxyURL)
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q (use http, https, or socks5)", proxyURL.Scheme)
+	}
+	return nil
+}
+
+// socks5DialContext returns a Transport.DialContext that tunnels every
+// connection through a SOCKS5 proxy (RFC 1928), supporting no-auth and
+// username/password authentication.
+func socks5DialContext(proxy *url.URL) func(context.Context, string, string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", proxy.Host)
+		if err != nil {
+			return nil, fmt.Errorf("dialing socks5 proxy: %w", err)
+		}
+		if err := socks5Handshake(conn, proxy, addr); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+func socks5Handshake(conn net.Conn, proxy *url.URL, targetAddr string) error {
+	methods := []byte{0x00} // no-auth
+	if proxy.User != nil {
+		methods = []byte{0x00, 0x02}
+	}
+	if _, err := conn.Write(append([]byte{0x05, byte(len(methods))}, methods...)); err != nil {
+		return fmt.Errorf("socks5: sending greeting: %w", err)
+	}
+
+	greetingResp := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greetingResp); err != nil {
+		return fmt.Errorf("socks5: reading greeting response: %w", err)
+	}
+	if greetingResp[0] != 0x05 {
+		return errors.New("socks5: unexpected protocol version in greeting response")
+	}
+
+	switch greetingResp[1] {
+	case 0x00:
+		// no authentication required
+	case 0x02:
+		if proxy.User == nil {
+			return errors.New("socks5: proxy requires username/password authentication")
+		}
+		if err := socks5Authenticate(conn, proxy.User); err != nil {
+			return err
+		}
+	default:
+		return errors.New("socks5: proxy offered no acceptable authentication method")
+	}
+
+	return socks5Connect(conn, targetAddr)
+}
+
+func socks5Authenticate(conn net.Conn, user *url.Userinfo) error {
+	password, _ := user.Password()
+	req := []byte{0x01, byte(len(user.Username()))}
+	req = append(req, user.Username()...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("socks5: sending auth request: %w", err)
+	}
+
+	resp := make([]byte, 2)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return fmt.Errorf("socks5: reading auth response: %w", err)
+	}
+	if resp[1] != 0x00 {
+		return errors.New("socks5: authentication failed")
+	}
+	return nil
+}
+
+func socks5Connect(conn net.Conn, targetAddr string) error {
+	host, portStr, err := net.SplitHostPort(targetAddr)
+	if err != nil {
+		return fmt.Errorf("socks5: invalid target address %q: %w", targetAddr, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("socks5: invalid target port %q: %w", portStr, err)
+	}
+
+	req := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+	req = append(req, host...)
+	portBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(portBytes, uint16(port))
+	req = append(req, portBytes...)
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("socks5: sending connect request: %w", err)
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return fmt.Errorf("socks5: reading connect response: %w", err)
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("socks5: connect request rejected with code %d", header[1])
+	}
+
+	var addrLen int
+	switch header[3] {
+	case 0x01:
+		addrLen = net.IPv4len
+	case 0x04:
+		addrLen = net.IPv6len
+	case 0x03:
+		lengthByte := make([]byte, 1)
+		if _, err := io.ReadFull(conn, lengthByte); err != nil {
+			return fmt.Errorf("socks5: reading bound address length: %w", err)
+		}
+		addrLen = int(lengthByte[0])
+	default:
+		return errors.New("socks5: unknown address type in connect response")
+	}
+	if _, err := io.ReadFull(conn, make([]byte, addrLen+2)); err != nil {
+		return fmt.Errorf("socks5: reading bound address: %w", err)
+	}
+
+	return nil
+}
+
+// RateLimiter is a per-host token bucket: it lets through up to `rps`
+// requests per second per host, so a large -t thread count can't hammer a
+// fragile target all at once. A nil *RateLimiter, or one built with rps<=0,
+// never throttles.
+type RateLimiter struct {
+	rps float64
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// NewRateLimiter builds a limiter allowing rps requests/second per host.
+func NewRateLimiter(rps float64) *RateLimiter {
+	return &RateLimiter{rps: rps, buckets: make(map[string]*tokenBucket)}
+}
+
+// Wait blocks until a request to host is allowed to proceed, or ctx is
+// canceled first.
+func (r *RateLimiter) Wait(ctx context.Context, host string) error {
+	if r == nil || r.rps <= 0 {
+		return nil
+	}
+	for {
+		wait := r.reserve(host)
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *RateLimiter) reserve(host string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := r.buckets[host]
+	if !ok {
+		r.buckets[host] = &tokenBucket{tokens: r.rps - 1, lastFill: now}
+		return 0
+	}
+
+	elapsed := now.Sub(bucket.lastFill).Seconds()
+	bucket.tokens += elapsed * r.rps
+	if bucket.tokens > r.rps {
+		bucket.tokens = r.rps
+	}
+	bucket.lastFill = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) / r.rps * float64(time.Second))
+}
@@ -2,14 +2,19 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/textproto"
+	neturl "net/url"
 	"os"
 	"os/exec"
-	"regexp"
+	"os/signal"
 	"strings"
 	"sync"
 	"time"
@@ -22,98 +27,358 @@ const (
 	Yellow     = "\033[93m"
 )
 
-// sql error pattern
-var sqlErrorPatterns = []*regexp.Regexp{
-	regexp.MustCompile(`(?i)mysql_fetch`),
-	regexp.MustCompile(`(?i)sql syntax`),
-	regexp.MustCompile(`(?i)mysql error`),
-	regexp.MustCompile(`(?i)unclosed quotation`),
-	regexp.MustCompile(`(?i)unknown column`),
-	regexp.MustCompile(`(?i)sql server`),
-	regexp.MustCompile(`(?i)sqlite3`),
-	regexp.MustCompile(`(?i)postgres`),
-}
+// ToolVersion is reported in the banner and in structured output formats.
+const ToolVersion = "0.2"
+
+// fuzzMarkers are the tokens a user can place in a URL, header, cookie or
+// body to mark an injection point, mirroring Burp/sqlmap conventions. A bare
+// "*" is deliberately not supported: it collides with ordinary request
+// content (e.g. an "Accept: */*" header), silently fuzzing the wrong spot.
+var fuzzMarkers = []string{"FUZZ"}
 
 type RequestResult struct {
 	Success      bool
+	Method       string
 	URL          string
 	ResponseTime float64
 	ErrorMsg     string
 	Body         string
 	BodySize     int
 	IsSQLi       bool
-	SQLiType     string // "time-based", "error-based", "none"
+	SQLiType     string // "time-based", "error-based", "anomaly-based", "boolean-based", "none"
 	BaselineTime float64
 	BaselineSize int // For response size comparison
+
+	// SimilarityTrue/SimilarityFalse are only populated for boolean-based
+	// checks: how closely the TRUE- and FALSE-condition responses matched
+	// the baseline response body.
+	SimilarityTrue  float64
+	SimilarityFalse float64
+
+	// InjectedParam is the "kind:name" label (e.g. "query:id", "path:2",
+	// "body:username") of the parameter the payload was injected into, or
+	// "all-params" when every parameter was fuzzed at once. Empty when the
+	// template carried an explicit FUZZ marker or had no discovered
+	// injection points.
+	InjectedParam string
+
+	// DBMS is the database engine identified by an error-based hit (e.g.
+	// "mysql", "postgresql"), or empty when the match was generic or no
+	// error-based hit fired.
+	DBMS string
+
+	// Payload is the raw payloads-file line that produced this result.
+	Payload string
+
+	// TamperChain is the comma-joined list of tamper transforms (-tamper)
+	// applied to Payload before it was sent, or empty when none were.
+	TamperChain string
 }
 
-func clearScreen() {
-	cmd := exec.Command("clear") // Linux
-	cmd.Stdout = os.Stdout
-	cmd.Run()
+// Baseline holds the reference response a target's injected requests are
+// compared against.
+type Baseline struct {
+	Time float64
+	Size int
+	Body string
 }
 
-func performRequest(url, payload, cookie string, timeout time.Duration) RequestResult {
-	urlWithPayload := url + payload
-	startTime := time.Now()
+// PayloadEntry is one line from the payloads file. A plain line is a single
+// payload for time/error/anomaly-based checks. A line containing
+// booleanPairDelimiter is a TRUE/FALSE pair for boolean-based blind checks,
+// e.g. `' AND 1=1-- ::: ' AND 1=2--`.
+type PayloadEntry struct {
+	Raw     string
+	True    string
+	False   string
+	Boolean bool
+}
 
-	client := &http.Client{
-		Timeout: timeout,
+// booleanPairDelimiter separates the TRUE and FALSE halves of a
+// boolean-based payload pair within a single payloads-file line.
+const booleanPairDelimiter = ":::"
+
+// parsePayloadLine turns one payloads-file line into a PayloadEntry.
+func parsePayloadLine(line string) PayloadEntry {
+	if truePart, falsePart, ok := strings.Cut(line, booleanPairDelimiter); ok {
+		return PayloadEntry{
+			Raw:     line,
+			True:    strings.TrimSpace(truePart),
+			False:   strings.TrimSpace(falsePart),
+			Boolean: true,
+		}
 	}
+	return PayloadEntry{Raw: line}
+}
 
-	req, err := http.NewRequest("GET", urlWithPayload, nil)
-	if err != nil {
-		return RequestResult{
-			Success:      false,
-			URL:          urlWithPayload,
-			ResponseTime: time.Since(startTime).Seconds(),
-			ErrorMsg:     err.Error(),
+// headerFlags collects repeatable -H "Name: Value" flags.
+type headerFlags []string
+
+func (h *headerFlags) String() string { return strings.Join(*h, ",") }
+
+func (h *headerFlags) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+// RequestTemplate describes one request to send, with FUZZ markers still in
+// place. injectPayload substitutes those markers with a concrete payload
+// before the request is fired.
+type RequestTemplate struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    string
+}
+
+// containsFuzzMarker reports whether s contains any of the recognised
+// injection-point tokens.
+func containsFuzzMarker(s string) bool {
+	for _, marker := range fuzzMarkers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceFuzzMarkers substitutes every occurrence of every recognised
+// injection-point token in s with payload.
+func replaceFuzzMarkers(s, payload string) string {
+	for _, marker := range fuzzMarkers {
+		s = strings.ReplaceAll(s, marker, payload)
+	}
+	return s
+}
+
+// templateHasFuzzMarker reports whether any part of the template (URL,
+// headers or body) carries an injection-point token.
+func (t RequestTemplate) hasFuzzMarker() bool {
+	if containsFuzzMarker(t.URL) || containsFuzzMarker(t.Body) {
+		return true
+	}
+	for name, values := range t.Headers {
+		if containsFuzzMarker(name) {
+			return true
+		}
+		for _, v := range values {
+			if containsFuzzMarker(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// injectPayload returns a copy of t with every FUZZ marker replaced by
+// payload. If t carries no marker at all, payload is appended to the URL
+// instead, preserving the scanner's original URL-suffix behavior.
+func injectPayload(t RequestTemplate, payload string) RequestTemplate {
+	if !t.hasFuzzMarker() {
+		t.URL = t.URL + payload
+		return t
+	}
+
+	out := t
+	out.URL = replaceFuzzMarkers(t.URL, payload)
+	out.Body = replaceFuzzMarkers(t.Body, payload)
+	out.Headers = make(http.Header, len(t.Headers))
+	for name, values := range t.Headers {
+		newName := replaceFuzzMarkers(name, payload)
+		for _, v := range values {
+			out.Headers.Add(newName, replaceFuzzMarkers(v, payload))
+		}
+	}
+	return out
+}
+
+// buildRequestTemplate assembles a RequestTemplate from plain CLI flags
+// (-X, -H, -d, -c), the conventional way to target a single endpoint.
+func buildRequestTemplate(url, method string, headers []string, cookie, data string) (RequestTemplate, error) {
+	tmpl := RequestTemplate{
+		Method:  strings.ToUpper(method),
+		URL:     url,
+		Headers: http.Header{},
+		Body:    data,
+	}
+
+	for _, raw := range headers {
+		name, value, ok := strings.Cut(raw, ":")
+		if !ok {
+			return RequestTemplate{}, fmt.Errorf("invalid header %q, expected \"Name: Value\"", raw)
 		}
+		tmpl.Headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
 	}
 
 	if cookie != "" {
-		req.Header.Add("Cookie", cookie)
+		tmpl.Headers.Set("Cookie", cookie)
 	}
 
-	resp, err := client.Do(req)
-	responseTime := time.Since(startTime).Seconds()
+	return tmpl, nil
+}
 
+// parseRequestTemplate reads a raw HTTP request file (à la Burp/sqlmap -r)
+// and turns it into a RequestTemplate. FUZZ markers may appear anywhere in
+// the request line, headers, or body. The request line's target is resolved
+// against the Host header to produce an absolute URL; useTLS selects
+// https:// over http:// when the template has no scheme of its own.
+func parseRequestTemplate(path string, useTLS bool) (RequestTemplate, error) {
+	raw, err := os.ReadFile(path)
 	if err != nil {
+		return RequestTemplate{}, fmt.Errorf("reading request template: %w", err)
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(raw))
+
+	requestLine, err := reader.ReadString('\n')
+	if err != nil && requestLine == "" {
+		return RequestTemplate{}, fmt.Errorf("request template is empty")
+	}
+	parts := strings.Fields(requestLine)
+	if len(parts) < 2 {
+		return RequestTemplate{}, fmt.Errorf("malformed request line %q", strings.TrimSpace(requestLine))
+	}
+	method, target := parts[0], parts[1]
+
+	tp := textproto.NewReader(reader)
+	mimeHeader, err := tp.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return RequestTemplate{}, fmt.Errorf("reading request template headers: %w", err)
+	}
+	headers := http.Header(mimeHeader)
+
+	bodyBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return RequestTemplate{}, fmt.Errorf("reading request template body: %w", err)
+	}
+	body := strings.TrimRight(string(bodyBytes), "\r\n")
+
+	host := headers.Get("Host")
+	if host == "" {
+		return RequestTemplate{}, fmt.Errorf("request template has no Host header")
+	}
+	headers.Del("Host")
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+
+	url := target
+	if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+		url = scheme + "://" + host + target
+	}
+
+	return RequestTemplate{
+		Method:  strings.ToUpper(method),
+		URL:     url,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+func clearScreen() {
+	cmd := exec.Command("clear") // Linux
+	cmd.Stdout = os.Stdout
+	cmd.Run()
+}
+
+// performRequest injects payload into tmpl and fires the resulting request
+// through client, rate-limited per host by limiter and retried per retry on
+// network errors or 5xx responses with exponential backoff. ctx governs
+// cancellation (per-request timeout, or the whole scan on Ctrl-C) and is
+// checked between retries so a cancellation doesn't spin through the rest
+// of the backoff schedule.
+func performRequest(ctx context.Context, client *http.Client, limiter *RateLimiter, retry RetryPolicy, tmpl RequestTemplate, payload string) RequestResult {
+	spec := injectPayload(tmpl, payload)
+	startTime := time.Now()
+
+	failed := func(err error) RequestResult {
 		return RequestResult{
 			Success:      false,
-			URL:          urlWithPayload,
-			ResponseTime: responseTime,
+			Method:       spec.Method,
+			URL:          spec.URL,
+			ResponseTime: time.Since(startTime).Seconds(),
 			ErrorMsg:     err.Error(),
 		}
 	}
-	defer resp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	parsedURL, err := neturl.Parse(spec.URL)
 	if err != nil {
+		return failed(fmt.Errorf("parsing request URL: %w", err))
+	}
+	if err := limiter.Wait(ctx, parsedURL.Host); err != nil {
+		return failed(err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(retry.backoff(attempt))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return failed(ctx.Err())
+			}
+		}
+
+		var bodyReader io.Reader
+		if spec.Body != "" {
+			bodyReader = strings.NewReader(spec.Body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, spec.Method, spec.URL, bodyReader)
+		if err != nil {
+			return failed(err)
+		}
+		req.Header = spec.Headers.Clone()
+
+		resp, err := client.Do(req)
+		responseTime := time.Since(startTime).Seconds()
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break // canceled or timed out: no point retrying
+			}
+			continue // network error: retry
+		}
+
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode >= 500 && attempt < retry.MaxRetries {
+			lastErr = fmt.Errorf("server error: %s", resp.Status)
+			continue
+		}
+
+		body := string(bodyBytes)
 		return RequestResult{
-			Success:      false,
-			URL:          urlWithPayload,
+			Success:      true,
+			Method:       spec.Method,
+			URL:          spec.URL,
 			ResponseTime: responseTime,
-			ErrorMsg:     err.Error(),
+			Body:         body,
+			BodySize:     len(body),
 		}
 	}
 
-	body := string(bodyBytes)
-	return RequestResult{
-		Success:      true,
-		URL:          urlWithPayload,
-		ResponseTime: responseTime,
-		Body:         body,
-		BodySize:     len(body),
+	if lastErr == nil {
+		lastErr = errors.New("request failed")
 	}
+	return failed(lastErr)
 }
 
-func getBaseline(url, cookie string, timeout time.Duration) (float64, int, error) {
-	result := performRequest(url, "", cookie, timeout)
+func getBaseline(ctx context.Context, client *http.Client, limiter *RateLimiter, retry RetryPolicy, tmpl RequestTemplate) (Baseline, error) {
+	result := performRequest(ctx, client, limiter, retry, tmpl, "")
 	if !result.Success {
-		return 0, 0, fmt.Errorf("baseline request failed: %s", result.ErrorMsg)
+		return Baseline{}, fmt.Errorf("baseline request failed: %s", result.ErrorMsg)
 	}
-	return result.ResponseTime, result.BodySize, nil
+	return Baseline{Time: result.ResponseTime, Size: result.BodySize, Body: result.Body}, nil
 }
 
 func analyzeSQLi(result RequestResult, baselineTime float64, baselineSize int) RequestResult {
@@ -125,12 +390,11 @@ func analyzeSQLi(result RequestResult, baselineTime float64, baselineSize int) R
 	}
 
 	// Error-based detection
-	for _, pattern := range sqlErrorPatterns {
-		if pattern.MatchString(result.Body) {
-			result.IsSQLi = true
-			result.SQLiType = "error-based"
-			return result
-		}
+	if dbms, matched := fingerprintDBMS(result.Body); matched {
+		result.IsSQLi = true
+		result.SQLiType = "error-based"
+		result.DBMS = dbms
+		return result
 	}
 
 	if baselineSize > 0 && (result.BodySize < baselineSize/2 || result.BodySize > baselineSize*2) {
@@ -143,6 +407,36 @@ func analyzeSQLi(result RequestResult, baselineTime float64, baselineSize int) R
 	return result
 }
 
+// analyzeBooleanSQLi compares the TRUE- and FALSE-condition responses of a
+// boolean-based payload pair against the baseline body. The URL is flagged
+// vulnerable when the TRUE response closely matches the baseline, the FALSE
+// response diverges from it, and the TRUE/FALSE pair diverge from each other
+// by at least the same margin - ruling out a page that is simply unstable.
+func analyzeBooleanSQLi(trueResult, falseResult RequestResult, baselineBody string, trueThreshold, falseThreshold float64) RequestResult {
+	result := trueResult
+	if !trueResult.Success || !falseResult.Success {
+		result.SQLiType = "none"
+		return result
+	}
+
+	result.SimilarityTrue = similarityRatio(trueResult.Body, baselineBody)
+	result.SimilarityFalse = similarityRatio(falseResult.Body, baselineBody)
+
+	margin := trueThreshold - falseThreshold
+	trueFalseDivergence := 1 - similarityRatio(trueResult.Body, falseResult.Body)
+
+	if result.SimilarityTrue >= trueThreshold &&
+		result.SimilarityFalse <= falseThreshold &&
+		trueFalseDivergence >= margin {
+		result.IsSQLi = true
+		result.SQLiType = "boolean-based"
+		return result
+	}
+
+	result.SQLiType = "none"
+	return result
+}
+
 func printBanner() {
 	banner := []string{
 		"       ░▒▓█▓▒░▒▓█▓▒░░▒▓█▓▒░▒▓███████▓▒░ ░▒▓██████▓▒░░▒▓█▓▒░      ░▒▓███████▓▒░░▒▓████████▓▒░▒▓█▓▒░░▒▓█▓▒░▒▓███████▓▒░░▒▓█▓▒░░▒▓█▓▒░ ",
@@ -165,17 +459,62 @@ func main() {
 	url := flag.String("u", "", "Single URL to scan")
 	urlList := flag.String("l", "", "Text file containing a list of URLs to scan")
 	payloadsFile := flag.String("p", "", "Text file containing the payloads (required)")
-	cookie := flag.String("c", "", "Cookie to include in the GET request")
+	cookie := flag.String("c", "", "Cookie to include in the request")
+	method := flag.String("X", "GET", "HTTP method to use (GET, POST, PUT, ...)")
+	data := flag.String("d", "", "Request body, e.g. form data or JSON; mark injection points with FUZZ")
+	reqFile := flag.String("r", "", "Raw HTTP request template file with FUZZ injection markers (Burp/sqlmap -r style)")
+	useTLS := flag.Bool("https", false, "Use https:// when building the URL from -r (ignored if the request line has a scheme)")
 	threads := flag.Int("t", 5, "Number of concurrent threads (1-20)")
 	logFile := flag.String("log", "sqli_scan.log", "Log file to store results")
+	boolTrueThreshold := flag.Float64("bool-true-threshold", 0.95, "Min similarity to baseline for a boolean-based TRUE response")
+	boolFalseThreshold := flag.Float64("bool-false-threshold", 0.85, "Max similarity to baseline for a boolean-based FALSE response")
+	pMode := flag.String("p-mode", "append", `How to inject payloads into discovered parameters when no FUZZ marker is present: "append" (default, add after the original value), "replace" (overwrite the original value), or "all-params" (fuzz every parameter at once instead of one at a time)`)
+	fuzzPath := flag.Bool("fuzz-path", false, "Also discover and fuzz URL path segments (off by default: mutating a path segment usually 404s rather than reaching the application, producing false anomaly-based findings)")
+	fingerprintFile := flag.String("fingerprint-file", "", "JSON file of {dbms: [pattern, ...]} overriding the built-in DBMS fingerprint signatures")
+	timeout := flag.Duration("timeout", 15*time.Second, "Per-request timeout")
+	rps := flag.Float64("rps", 0, "Max requests per second per host (0 = unlimited)")
+	proxy := flag.String("proxy", "", "Upstream proxy URL (http://, https://, or socks5://)")
+	maxRetries := flag.Int("retries", defaultRetryPolicy.MaxRetries, "Max retries on network errors or 5xx responses")
+	retryBackoff := flag.Duration("retry-backoff", defaultRetryPolicy.BaseDelay, "Base delay for exponential retry backoff")
+	outputFormat := flag.String("o", "text", "Output format: text, jsonl, json, or sarif")
+	outputFile := flag.String("output-file", "", "File to write results to (default: stdout)")
+	tamperSpec := flag.String("tamper", "", "Comma-separated tamper chain applied to every payload, e.g. space2comment,randomcase,charunicodeencode (available: space2comment, randomcase, charunicodeencode, between, apostrophenullencode, equaltolike)")
+	var headers headerFlags
+	flag.Var(&headers, "H", `Custom header "Name: Value" (repeatable); mark injection points with FUZZ`)
 
 	flag.Parse()
 
-	if *payloadsFile == "" || (*url == "" && *urlList == "") {
+	if *payloadsFile == "" || (*url == "" && *urlList == "" && *reqFile == "") {
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	if *fingerprintFile != "" {
+		if err := loadSignatureFile(*fingerprintFile); err != nil {
+			fmt.Printf("%s✗ Error loading fingerprint file: %s%s\n", Red, err, Reset)
+			os.Exit(1)
+		}
+	}
+
+	tamperChain, err := parseTamperChain(*tamperSpec)
+	if err != nil {
+		fmt.Printf("%s✗ Error parsing -tamper: %s%s\n", Red, err, Reset)
+		os.Exit(1)
+	}
+
+	httpClient, err := newHTTPClient(*proxy)
+	if err != nil {
+		fmt.Printf("%s✗ Error configuring proxy: %s%s\n", Red, err, Reset)
+		os.Exit(1)
+	}
+	limiter := NewRateLimiter(*rps)
+	retry := RetryPolicy{MaxRetries: *maxRetries, BaseDelay: *retryBackoff}
+
+	// Ctrl-C cancels every in-flight request instead of leaving the scan
+	// to hang until each one's own timeout expires.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	logFileHandle, err := os.OpenFile(*logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		fmt.Printf("%s✗ Error opening log file: %s%s\n", Red, err, Reset)
@@ -184,20 +523,55 @@ func main() {
 	defer logFileHandle.Close()
 	logger := log.New(logFileHandle, "SQLiScanner: ", log.LstdFlags)
 
-	var urls []string
-	if *url != "" {
-		urls = append(urls, *url)
-	} else {
-		file, err := os.Open(*urlList)
+	outputWriter := io.Writer(os.Stdout)
+	if *outputFile != "" {
+		outputFileHandle, err := os.Create(*outputFile)
 		if err != nil {
-			fmt.Printf("%s✗ Error opening URL list: %s%s\n", Red, err, Reset)
+			fmt.Printf("%s✗ Error creating output file: %s%s\n", Red, err, Reset)
 			os.Exit(1)
 		}
-		defer file.Close()
+		defer outputFileHandle.Close()
+		outputWriter = outputFileHandle
+	}
+	reporter, err := NewReporter(*outputFormat, outputWriter, logger)
+	if err != nil {
+		fmt.Printf("%s✗ %s%s\n", Red, err, Reset)
+		os.Exit(1)
+	}
 
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			urls = append(urls, strings.TrimSpace(scanner.Text()))
+	var templates []RequestTemplate
+	if *reqFile != "" {
+		tmpl, err := parseRequestTemplate(*reqFile, *useTLS)
+		if err != nil {
+			fmt.Printf("%s✗ Error parsing request template: %s%s\n", Red, err, Reset)
+			os.Exit(1)
+		}
+		templates = append(templates, tmpl)
+	} else {
+		var urls []string
+		if *url != "" {
+			urls = append(urls, *url)
+		} else {
+			file, err := os.Open(*urlList)
+			if err != nil {
+				fmt.Printf("%s✗ Error opening URL list: %s%s\n", Red, err, Reset)
+				os.Exit(1)
+			}
+			defer file.Close()
+
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				urls = append(urls, strings.TrimSpace(scanner.Text()))
+			}
+		}
+
+		for _, u := range urls {
+			tmpl, err := buildRequestTemplate(u, *method, headers, *cookie, *data)
+			if err != nil {
+				fmt.Printf("%s✗ Error building request for %s: %s%s\n", Red, u, err, Reset)
+				os.Exit(1)
+			}
+			templates = append(templates, tmpl)
 		}
 	}
 
@@ -208,41 +582,97 @@ func main() {
 	}
 	defer payloadsFileHandle.Close()
 
-	var payloads []string
+	var payloads []PayloadEntry
 	scanner := bufio.NewScanner(payloadsFileHandle)
 	for scanner.Scan() {
-		payloads = append(payloads, strings.TrimSpace(scanner.Text()))
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		payloads = append(payloads, parsePayloadLine(line))
 	}
 
-	printBanner()
+	if *outputFormat == "" || *outputFormat == "text" {
+		printBanner()
+	}
 
 	var wg sync.WaitGroup
-	results := make(chan RequestResult, len(urls)*len(payloads))
+	results := make(chan RequestResult, len(templates)*len(payloads))
 	semaphore := make(chan struct{}, *threads)
 	if *threads < 1 || *threads > 20 {
 		*threads = 5 // Default to 5 if out of range
 	}
 
-	for _, url := range urls {
-		baselineTime, baselineSize, err := getBaseline(url, *cookie, 15*time.Second)
+	newRequestCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, *timeout)
+	}
+
+	for _, tmpl := range templates {
+		if ctx.Err() != nil {
+			break
+		}
+
+		baselineCtx, cancel := newRequestCtx()
+		baseline, err := getBaseline(baselineCtx, httpClient, limiter, retry, tmpl)
+		cancel()
 		if err != nil {
-			fmt.Printf("%s✗ Failed to get baseline for %s: %s%s\n", Red, url, err, Reset)
-			logger.Printf("Baseline failure for %s: %s", url, err)
+			fmt.Printf("%s✗ Failed to get baseline for %s: %s%s\n", Red, tmpl.URL, err, Reset)
+			logger.Printf("Baseline failure for %s: %s", tmpl.URL, err)
+			continue
+		}
+
+		target, err := parseTarget(tmpl, *fuzzPath)
+		if err != nil {
+			fmt.Printf("%s✗ Failed to parse target %s: %s%s\n", Red, tmpl.URL, err, Reset)
+			logger.Printf("Target parse failure for %s: %s", tmpl.URL, err)
 			continue
 		}
 
 		for _, payload := range payloads {
 			wg.Add(1)
-			go func(u, p string, bt float64, bs int) {
+			go func(t RequestTemplate, tg Target, p PayloadEntry, b Baseline) {
 				defer wg.Done()
 				semaphore <- struct{}{}
-				result := performRequest(u, p, *cookie, 15*time.Second)
-				result.BaselineTime = bt
-				result.BaselineSize = bs
-				result = analyzeSQLi(result, bt, bs)
-				results <- result
-				<-semaphore
-			}(url, payload, baselineTime, baselineSize)
+				defer func() { <-semaphore }()
+
+				if p.Boolean {
+					tamperedTrue, chainLabel := applyTamperChain(tamperChain, p.True)
+					tamperedFalse, _ := applyTamperChain(tamperChain, p.False)
+					trueReqs := buildParamRequests(t, tg, tamperedTrue, *pMode)
+					falseReqs := buildParamRequests(t, tg, tamperedFalse, *pMode)
+					for i := range trueReqs {
+						trueCtx, trueCancel := newRequestCtx()
+						trueResult := performRequest(trueCtx, httpClient, limiter, retry, trueReqs[i].Template, "")
+						trueCancel()
+
+						falseCtx, falseCancel := newRequestCtx()
+						falseResult := performRequest(falseCtx, httpClient, limiter, retry, falseReqs[i].Template, "")
+						falseCancel()
+
+						trueResult.BaselineTime = b.Time
+						trueResult.BaselineSize = b.Size
+						trueResult.InjectedParam = trueReqs[i].Param
+						trueResult.Payload = p.Raw
+						trueResult.TamperChain = chainLabel
+						results <- analyzeBooleanSQLi(trueResult, falseResult, b.Body, *boolTrueThreshold, *boolFalseThreshold)
+					}
+					return
+				}
+
+				tamperedRaw, chainLabel := applyTamperChain(tamperChain, p.Raw)
+				for _, pr := range buildParamRequests(t, tg, tamperedRaw, *pMode) {
+					reqCtx, reqCancel := newRequestCtx()
+					result := performRequest(reqCtx, httpClient, limiter, retry, pr.Template, "")
+					reqCancel()
+
+					result.BaselineTime = b.Time
+					result.BaselineSize = b.Size
+					result.InjectedParam = pr.Param
+					result.Payload = p.Raw
+					result.TamperChain = chainLabel
+					results <- analyzeSQLi(result, b.Time, b.Size)
+				}
+			}(tmpl, target, payload, baseline)
 		}
 	}
 
@@ -252,26 +682,11 @@ func main() {
 	}()
 
 	for result := range results {
-		switch result.SQLiType {
-		case "time-based":
-			fmt.Printf("%s✓ Time-Based SQLi Found! URL: %s - Response Time: %.2f s (Baseline: %.2f s)%s\n",
-				LightGreen, result.URL, result.ResponseTime, result.BaselineTime, Reset)
-			logger.Printf("Time-Based SQLi: %s - Time: %.2f s", result.URL, result.ResponseTime)
-		case "error-based":
-			fmt.Printf("%s✓ Error-Based SQLi Found! URL: %s - Response Time: %.2f s%s\n",
-				Yellow, result.URL, result.ResponseTime, Reset)
-			logger.Printf("Error-Based SQLi: %s - Time: %.2f s", result.URL, result.ResponseTime)
-		case "anomaly-based":
-			fmt.Printf("%s✓ Anomaly-Based SQLi Detected! URL: %s - Size: %d (Baseline: %d)%s\n",
-				Yellow, result.URL, result.BodySize, result.BaselineSize, Reset)
-			logger.Printf("Anomaly-Based SQLi: %s - Size: %d", result.URL, result.BodySize)
-		case "none":
-			fmt.Printf("%s✗ Not Vulnerable. URL: %s - Response Time: %.2f s%s\n",
-				Red, result.URL, result.ResponseTime, Reset)
-		}
-		if result.ErrorMsg != "" {
-			fmt.Printf("%s✗ Error: %s%s\n", Red, result.ErrorMsg, Reset)
-			logger.Printf("Error: %s - %s", result.URL, result.ErrorMsg)
-		}
+		reporter.OnResult(result)
+	}
+
+	if err := reporter.Close(); err != nil {
+		fmt.Printf("%s✗ Error writing output: %s%s\n", Red, err, Reset)
+		os.Exit(1)
 	}
 }
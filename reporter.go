@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Reporter consumes results as they stream in from the scan and finalizes
+// output (writing any buffered summary) once the scan completes, so CI
+// systems can ingest findings in whatever shape they need.
+type Reporter interface {
+	OnResult(result RequestResult)
+	Close() error
+}
+
+// NewReporter builds the Reporter for format ("text", "jsonl", "json", or
+// "sarif", "text" is the default), writing to w.
+func NewReporter(format string, w io.Writer, logger *log.Logger) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return &textReporter{w: w, logger: logger}, nil
+	case "jsonl":
+		return &jsonlReporter{enc: json.NewEncoder(w)}, nil
+	case "json":
+		return &jsonReporter{w: w, startedAt: time.Now()}, nil
+	case "sarif":
+		return &sarifReporter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (use text, jsonl, json, or sarif)", format)
+	}
+}
+
+// textReporter reproduces the scanner's original colored, human-readable
+// output, plus the SQLiScanner log file.
+type textReporter struct {
+	w      io.Writer
+	logger *log.Logger
+}
+
+func (t *textReporter) OnResult(result RequestResult) {
+	param := ""
+	if result.InjectedParam != "" {
+		param = fmt.Sprintf(" - Param: %s", result.InjectedParam)
+	}
+	if result.TamperChain != "" {
+		param += fmt.Sprintf(" - Tamper: %s", result.TamperChain)
+	}
+	switch result.SQLiType {
+	case "time-based":
+		fmt.Fprintf(t.w, "%s✓ Time-Based SQLi Found! URL: %s - Response Time: %.2f s (Baseline: %.2f s)%s%s\n",
+			LightGreen, result.URL, result.ResponseTime, result.BaselineTime, param, Reset)
+		t.logger.Printf("Time-Based SQLi: %s - Time: %.2f s%s", result.URL, result.ResponseTime, param)
+	case "error-based":
+		dbms := result.DBMS
+		if dbms == "" {
+			dbms = "unknown"
+		}
+		fmt.Fprintf(t.w, "%s✓ Error-Based SQLi Found! URL: %s - DBMS: %s - Response Time: %.2f s%s%s\n",
+			Yellow, result.URL, dbms, result.ResponseTime, param, Reset)
+		t.logger.Printf("Error-Based SQLi: %s - DBMS: %s - Time: %.2f s%s", result.URL, dbms, result.ResponseTime, param)
+	case "anomaly-based":
+		fmt.Fprintf(t.w, "%s✓ Anomaly-Based SQLi Detected! URL: %s - Size: %d (Baseline: %d)%s%s\n",
+			Yellow, result.URL, result.BodySize, result.BaselineSize, param, Reset)
+		t.logger.Printf("Anomaly-Based SQLi: %s - Size: %d%s", result.URL, result.BodySize, param)
+	case "boolean-based":
+		fmt.Fprintf(t.w, "%s✓ Boolean-Based Blind SQLi Found! URL: %s - Similarity TRUE: %.2f, FALSE: %.2f%s%s\n",
+			LightGreen, result.URL, result.SimilarityTrue, result.SimilarityFalse, param, Reset)
+		t.logger.Printf("Boolean-Based SQLi: %s - Similarity TRUE: %.2f, FALSE: %.2f%s", result.URL, result.SimilarityTrue, result.SimilarityFalse, param)
+	case "none":
+		fmt.Fprintf(t.w, "%s✗ Not Vulnerable. URL: %s - Response Time: %.2f s%s\n",
+			Red, result.URL, result.ResponseTime, Reset)
+	}
+	if result.ErrorMsg != "" {
+		fmt.Fprintf(t.w, "%s✗ Error: %s%s\n", Red, result.ErrorMsg, Reset)
+		t.logger.Printf("Error: %s - %s", result.URL, result.ErrorMsg)
+	}
+}
+
+func (t *textReporter) Close() error { return nil }
+
+// jsonlReporter writes one RequestResult per line as it arrives, so a
+// downstream pipeline can start consuming findings before the scan ends.
+type jsonlReporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (j *jsonlReporter) OnResult(result RequestResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.enc.Encode(result)
+}
+
+func (j *jsonlReporter) Close() error { return nil }
+
+// jsonScanOutput is the document written by jsonReporter: every result,
+// plus metadata about the scan that produced them.
+type jsonScanOutput struct {
+	Tool      string          `json:"tool"`
+	Version   string          `json:"version"`
+	StartedAt time.Time       `json:"started_at"`
+	EndedAt   time.Time       `json:"ended_at"`
+	Results   []RequestResult `json:"results"`
+}
+
+// jsonReporter buffers every result and writes a single JSON document on
+// Close.
+type jsonReporter struct {
+	w         io.Writer
+	startedAt time.Time
+
+	mu      sync.Mutex
+	results []RequestResult
+}
+
+func (j *jsonReporter) OnResult(result RequestResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, result)
+}
+
+func (j *jsonReporter) Close() error {
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonScanOutput{
+		Tool:      "zsqli",
+		Version:   ToolVersion,
+		StartedAt: j.startedAt,
+		EndedAt:   time.Now(),
+		Results:   j.results,
+	})
+}
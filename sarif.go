@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// sarifReporter writes every vulnerable result as a SARIF 2.1.0 result
+// under a single SQLiScanner tool run, for ingestion by SARIF-aware CI
+// systems (GitHub code scanning, etc).
+type sarifReporter struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	results []sarifResult
+}
+
+func (s *sarifReporter) OnResult(result RequestResult) {
+	if !result.IsSQLi {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, sarifResult{
+		RuleID:  result.SQLiType,
+		Message: sarifMessage{Text: fmt.Sprintf("%s SQL injection via payload %q", result.SQLiType, result.Payload)},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: result.URL},
+			},
+		}},
+	})
+}
+
+func (s *sarifReporter) Close() error {
+	rules := make([]sarifRule, len(sarifRuleIDs))
+	for i, id := range sarifRuleIDs {
+		rules[i] = sarifRule{ID: id}
+	}
+
+	report := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:    "SQLiScanner",
+				Version: ToolVersion,
+				Rules:   rules,
+			}},
+			Results: s.results,
+		}},
+	}
+
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// sarifRuleIDs enumerates every SQLiType that can appear as a SARIF rule.
+var sarifRuleIDs = []string{"time-based", "error-based", "anomaly-based", "boolean-based"}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
@@ -0,0 +1,88 @@
+package main
+
+import "regexp"
+
+// dynamicTokenPatterns match response fragments that change between
+// otherwise-identical responses (CSRF tokens, timestamps) and would
+// otherwise tank the similarity score used by boolean-based detection.
+var dynamicTokenPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(csrf[-_]?token|authenticity_token|__requestverificationtoken)["'=:\s]+[a-zA-Z0-9+/=_-]{8,}`),
+	regexp.MustCompile(`\b\d{10,13}\b`),                              // unix timestamps (s or ms)
+	regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}\b`), // ISO-ish datetimes
+}
+
+// stripDynamicTokens removes known dynamic fragments from a response body
+// before it is compared against another response.
+func stripDynamicTokens(body string) string {
+	for _, pattern := range dynamicTokenPatterns {
+		body = pattern.ReplaceAllString(body, "")
+	}
+	return body
+}
+
+// similarityMaxRunes caps how much of each response levenshteinDistance
+// compares. The algorithm is O(n*m), and boolean-based mode runs three
+// comparisons per payload pair per thread, so diffing full, untruncated
+// bodies in the tens-of-KB range makes a scan unusably slow; a page's
+// opening bytes are enough to tell a TRUE response from a FALSE one.
+const similarityMaxRunes = 4096
+
+// similarityRatio returns a 0..1 score for how alike a and b are, based on
+// Levenshtein distance normalized by the longer string's length, after
+// stripping dynamic tokens from both and truncating to similarityMaxRunes.
+// 1 means identical.
+func similarityRatio(a, b string) float64 {
+	a = stripDynamicTokens(a)
+	b = stripDynamicTokens(b)
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) > similarityMaxRunes {
+		ra = ra[:similarityMaxRunes]
+	}
+	if len(rb) > similarityMaxRunes {
+		rb = rb[:similarityMaxRunes]
+	}
+
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+	if maxLen == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshteinDistance(ra, rb))/float64(maxLen)
+}
+
+// levenshteinDistance computes the edit distance between a and b using the
+// standard two-row dynamic-programming algorithm.
+func levenshteinDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, minInt(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
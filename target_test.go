@@ -0,0 +1,118 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestTargetMutateQuery(t *testing.T) {
+	tmpl := RequestTemplate{Method: "GET", URL: "http://host/search?id=1&name=bob", Headers: http.Header{}}
+	target, err := parseTarget(tmpl, false)
+	if err != nil {
+		t.Fatalf("parseTarget: %v", err)
+	}
+
+	var idPoint InjectionPoint
+	for _, p := range target.Points {
+		if p.Kind == "query" && p.Name == "id" {
+			idPoint = p
+		}
+	}
+	if idPoint.Name == "" {
+		t.Fatalf("expected to discover query param %q, got points %+v", "id", target.Points)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		mode    string
+		want    string
+	}{
+		{"append", "'", "append", "http://host/search?id=1%27&name=bob"},
+		{"replace", "'", "replace", "http://host/search?id=%27&name=bob"},
+		{"preserves pre-encoded percent", "%27 OR 1=1--", "append", "http://host/search?id=1%27+OR+1%3D1--&name=bob"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := target.mutate(idPoint, tc.payload, tc.mode)
+			if out.URL != tc.want {
+				t.Errorf("mutate(%q, %q) = %q, want %q", tc.payload, tc.mode, out.URL, tc.want)
+			}
+		})
+	}
+}
+
+func TestTargetMutateLeavesOtherParamsUntouched(t *testing.T) {
+	tmpl := RequestTemplate{Method: "GET", URL: "http://host/search?id=1&name=bob", Headers: http.Header{}}
+	target, err := parseTarget(tmpl, false)
+	if err != nil {
+		t.Fatalf("parseTarget: %v", err)
+	}
+
+	var idPoint InjectionPoint
+	for _, p := range target.Points {
+		if p.Name == "id" {
+			idPoint = p
+		}
+	}
+
+	out := target.mutate(idPoint, "'", "append")
+	if got := parseQueryValue(t, out.URL, "name"); got != "bob" {
+		t.Errorf("name param changed: got %q, want %q", got, "bob")
+	}
+}
+
+func TestTargetMutateAll(t *testing.T) {
+	tmpl := RequestTemplate{Method: "GET", URL: "http://host/search?id=1&name=bob", Headers: http.Header{}}
+	target, err := parseTarget(tmpl, false)
+	if err != nil {
+		t.Fatalf("parseTarget: %v", err)
+	}
+
+	out := target.mutateAll("'", "append")
+	if got := parseQueryValue(t, out.URL, "id"); got != "1'" {
+		t.Errorf("id = %q, want %q", got, "1'")
+	}
+	if got := parseQueryValue(t, out.URL, "name"); got != "bob'" {
+		t.Errorf("name = %q, want %q", got, "bob'")
+	}
+}
+
+func TestTargetApplyFormBody(t *testing.T) {
+	tmpl := RequestTemplate{
+		Method:  "POST",
+		URL:     "http://host/login",
+		Headers: http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+		Body:    "user=admin&pass=secret",
+	}
+	target, err := parseTarget(tmpl, false)
+	if err != nil {
+		t.Fatalf("parseTarget: %v", err)
+	}
+
+	var passPoint InjectionPoint
+	for _, p := range target.Points {
+		if p.Kind == "body" && p.Name == "pass" {
+			passPoint = p
+		}
+	}
+	if passPoint.Name == "" {
+		t.Fatalf("expected to discover body field %q, got points %+v", "pass", target.Points)
+	}
+
+	out := target.mutate(passPoint, "%27 OR 1=1--", "replace")
+	if out.Body != "pass=%27+OR+1%3D1--&user=admin" {
+		t.Errorf("Body = %q, want %q", out.Body, "pass=%27+OR+1%3D1--&user=admin")
+	}
+}
+
+func parseQueryValue(t *testing.T, rawURL, key string) string {
+	t.Helper()
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parsing URL %q: %v", rawURL, err)
+	}
+	return parsed.Query().Get(key)
+}